@@ -0,0 +1,184 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// extractTarGzFile extracts a .tar.gz file to a specified folder path.
+// stripComponents removes that many leading path elements from each entry's
+// name before it is joined with outputFolderPath (mirroring `tar --strip-components`),
+// which lets callers drop an archive's top-level directory when desired.
+func extractTarGzFile(tarGzPath, outputFolderPath string, stripComponents int) error {
+	// Open the tar.gz file
+	file, err := os.Open(tarGzPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Create a gzip reader
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	// Create a tar reader
+	tarReader := tar.NewReader(gzipReader)
+
+	destRoot, err := filepath.Abs(outputFolderPath)
+	if err != nil {
+		return err
+	}
+
+	// Extract files from the tar archive
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			// Reached the end of the archive
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		// git archive and some GitHub-generated tarballs include a synthetic
+		// entry carrying pax extended attributes for the following header;
+		// it has no content of its own and must be skipped.
+		if header.Name == "pax_global_header" {
+			continue
+		}
+
+		name, err := stripPathComponents(header.Name, stripComponents)
+		if err != nil {
+			return fmt.Errorf("extracting %q: %w", header.Name, err)
+		}
+		if name == "" {
+			continue
+		}
+
+		outputPath, err := safeJoin(destRoot, name)
+		if err != nil {
+			return fmt.Errorf("extracting %q: %w", header.Name, err)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(outputPath, os.FileMode(header.Mode&0777)); err != nil {
+				return err
+			}
+			if err := os.Chmod(outputPath, os.FileMode(header.Mode&0777)); err != nil {
+				return err
+			}
+
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+				return err
+			}
+
+			outFile, err := os.OpenFile(outputPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(header.Mode&0777))
+			if err != nil {
+				return err
+			}
+
+			_, err = io.Copy(outFile, tarReader)
+			closeErr := outFile.Close()
+			if err != nil {
+				return err
+			}
+			if closeErr != nil {
+				return closeErr
+			}
+
+		case tar.TypeSymlink:
+			linkTarget, err := safeSymlinkTarget(destRoot, outputPath, header.Linkname)
+			if err != nil {
+				return fmt.Errorf("extracting symlink %q: %w", header.Name, err)
+			}
+			if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+				return err
+			}
+			os.Remove(outputPath)
+			if err := os.Symlink(linkTarget, outputPath); err != nil {
+				return err
+			}
+
+		case tar.TypeLink:
+			linkName, err := stripPathComponents(header.Linkname, stripComponents)
+			if err != nil {
+				return fmt.Errorf("extracting hard link %q: %w", header.Name, err)
+			}
+			linkTargetPath, err := safeJoin(destRoot, linkName)
+			if err != nil {
+				return fmt.Errorf("extracting hard link %q: %w", header.Name, err)
+			}
+			if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+				return err
+			}
+			os.Remove(outputPath)
+			if err := os.Link(linkTargetPath, outputPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// stripPathComponents removes up to n leading "/"-separated elements from
+// name, using tar's normalized forward-slash form regardless of OS. It
+// returns "" if stripping consumes the whole name (the caller should skip
+// such entries, same as GNU tar does), and errors on absolute paths or any
+// ".." component, which path.Clean cannot safely resolve without silently
+// erasing evidence of a Zip Slip-style traversal attempt.
+func stripPathComponents(name string, n int) (string, error) {
+	cleaned := path.Clean(name)
+
+	if path.IsAbs(cleaned) {
+		return "", fmt.Errorf("illegal absolute path: %q", name)
+	}
+
+	parts := strings.Split(cleaned, "/")
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." {
+			return "", fmt.Errorf("illegal path traversal: %q", name)
+		}
+	}
+
+	if cleaned == "." || n >= len(parts) {
+		return "", nil
+	}
+	return filepath.Join(parts[n:]...), nil
+}
+
+// safeJoin resolves name against destRoot and rejects any result that
+// escapes destRoot, guarding against Zip Slip-style path traversal via
+// entries like "../../etc/passwd" or absolute paths.
+func safeJoin(destRoot, name string) (string, error) {
+	target := filepath.Join(destRoot, name)
+	if target != destRoot && !strings.HasPrefix(target, destRoot+string(os.PathSeparator)) {
+		return "", fmt.Errorf("illegal path traversal: %q escapes %q", name, destRoot)
+	}
+	return target, nil
+}
+
+// safeSymlinkTarget resolves a symlink's link name the way the kernel would
+// (relative to the symlink's own directory, unless absolute) and rejects any
+// target that would resolve outside destRoot.
+func safeSymlinkTarget(destRoot, outputPath, linkname string) (string, error) {
+	resolved := filepath.Clean(linkname)
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(filepath.Dir(outputPath), linkname)
+	}
+	if resolved != destRoot && !strings.HasPrefix(resolved, destRoot+string(os.PathSeparator)) {
+		return "", fmt.Errorf("illegal symlink target: %q escapes %q", linkname, destRoot)
+	}
+	return linkname, nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// tarEntry describes one entry to synthesize into a test tarball.
+type tarEntry struct {
+	name     string
+	typeflag byte
+	mode     int64
+	linkname string
+	body     string
+}
+
+// buildTarGz writes entries into an in-memory .tar.gz file and returns its path.
+func buildTarGz(t *testing.T, dir string, entries []tarEntry) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+
+	for _, e := range entries {
+		hdr := &tar.Header{
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Mode:     e.mode,
+			Linkname: e.linkname,
+			Size:     int64(len(e.body)),
+		}
+		if hdr.Mode == 0 {
+			hdr.Mode = 0644
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("WriteHeader(%q): %v", e.name, err)
+		}
+		if e.body != "" {
+			if _, err := tw.Write([]byte(e.body)); err != nil {
+				t.Fatalf("Write(%q): %v", e.name, err)
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar Close: %v", err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("gzip Close: %v", err)
+	}
+
+	archivePath := filepath.Join(dir, "test.tar.gz")
+	if err := os.WriteFile(archivePath, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return archivePath
+}
+
+func TestExtractTarGzFile(t *testing.T) {
+	tests := []struct {
+		name            string
+		entries         []tarEntry
+		stripComponents int
+		wantErr         bool
+		check           func(t *testing.T, dest string)
+	}{
+		{
+			name: "regular files and directories",
+			entries: []tarEntry{
+				{name: "GE-Proton9-1/", typeflag: tar.TypeDir, mode: 0755},
+				{name: "GE-Proton9-1/bin/wine", typeflag: tar.TypeReg, mode: 0755, body: "binary"},
+			},
+			check: func(t *testing.T, dest string) {
+				data, err := os.ReadFile(filepath.Join(dest, "GE-Proton9-1/bin/wine"))
+				if err != nil {
+					t.Fatalf("ReadFile: %v", err)
+				}
+				if string(data) != "binary" {
+					t.Errorf("got %q, want %q", data, "binary")
+				}
+			},
+		},
+		{
+			name: "strips leading path components",
+			entries: []tarEntry{
+				{name: "GE-Proton9-1/bin/wine", typeflag: tar.TypeReg, mode: 0755, body: "binary"},
+			},
+			stripComponents: 1,
+			check: func(t *testing.T, dest string) {
+				if _, err := os.Stat(filepath.Join(dest, "bin/wine")); err != nil {
+					t.Errorf("expected stripped path to exist: %v", err)
+				}
+			},
+		},
+		{
+			name: "rejects path traversal via ..",
+			entries: []tarEntry{
+				{name: "../../etc/passwd", typeflag: tar.TypeReg, body: "pwned"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects absolute path traversal",
+			entries: []tarEntry{
+				{name: "/etc/passwd", typeflag: tar.TypeReg, body: "pwned"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rejects symlink escaping destination",
+			entries: []tarEntry{
+				{name: "evil-link", typeflag: tar.TypeSymlink, linkname: "../../../etc/passwd"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accepts symlink within destination",
+			entries: []tarEntry{
+				{name: "real", typeflag: tar.TypeReg, body: "data"},
+				{name: "alias", typeflag: tar.TypeSymlink, linkname: "real"},
+			},
+			check: func(t *testing.T, dest string) {
+				target, err := os.Readlink(filepath.Join(dest, "alias"))
+				if err != nil {
+					t.Fatalf("Readlink: %v", err)
+				}
+				if target != "real" {
+					t.Errorf("got symlink target %q, want %q", target, "real")
+				}
+			},
+		},
+		{
+			name: "rejects hard link escaping destination",
+			entries: []tarEntry{
+				{name: "evil-hardlink", typeflag: tar.TypeLink, linkname: "../../../etc/passwd"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "skips pax_global_header entries",
+			entries: []tarEntry{
+				{name: "pax_global_header", typeflag: tar.TypeReg, body: "ignored"},
+				{name: "file", typeflag: tar.TypeReg, body: "data"},
+			},
+			check: func(t *testing.T, dest string) {
+				if _, err := os.Stat(filepath.Join(dest, "file")); err != nil {
+					t.Errorf("expected file to exist: %v", err)
+				}
+			},
+		},
+		{
+			name: "honors file mode from header",
+			entries: []tarEntry{
+				{name: "script", typeflag: tar.TypeReg, mode: 0700, body: "#!/bin/sh"},
+			},
+			check: func(t *testing.T, dest string) {
+				info, err := os.Stat(filepath.Join(dest, "script"))
+				if err != nil {
+					t.Fatalf("Stat: %v", err)
+				}
+				if info.Mode().Perm() != 0700 {
+					t.Errorf("got mode %v, want %v", info.Mode().Perm(), os.FileMode(0700))
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srcDir := t.TempDir()
+			destDir := t.TempDir()
+
+			archivePath := buildTarGz(t, srcDir, tt.entries)
+
+			err := extractTarGzFile(archivePath, destDir, tt.stripComponents)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("extractTarGzFile() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("extractTarGzFile() error = %v", err)
+			}
+			if tt.check != nil {
+				tt.check(t, destDir)
+			}
+		})
+	}
+}
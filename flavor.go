@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Flavor describes a GE release family — e.g. Proton-GE for Steam, Wine-GE
+// for Lutris — so the rest of the tool can work with either instead of
+// hardcoding GloriousEggroll/proton-ge-custom and the Steam compatibility
+// tools directory everywhere.
+type Flavor struct {
+	Name            string
+	Owner           string
+	Repo            string
+	InstallDir      string // relative to $HOME
+	AssetMatcher    func(name string) bool
+	StripComponents int
+}
+
+// flavors are the GE release families this tool knows how to install.
+var flavors = map[string]Flavor{
+	"proton-ge": {
+		Name:       "proton-ge",
+		Owner:      "GloriousEggroll",
+		Repo:       "proton-ge-custom",
+		InstallDir: ".steam/steam/compatibilitytools.d",
+		AssetMatcher: func(name string) bool {
+			return strings.HasSuffix(name, ".tar.gz")
+		},
+	},
+	"wine-ge": {
+		Name:       "wine-ge",
+		Owner:      "GloriousEggroll",
+		Repo:       "wine-ge-custom",
+		InstallDir: ".local/share/lutris/runners/wine",
+		// wine-ge-custom also publishes a .tar.xz asset on some releases, but
+		// extractTarGzFile only understands gzip; only match the .tar.gz one
+		// until xz support exists.
+		AssetMatcher: func(name string) bool {
+			return strings.HasSuffix(name, ".tar.gz")
+		},
+		// Unlike Proton-GE, whose tarball's top-level directory is named
+		// after the release tag, Wine-GE's is named after the asset (e.g.
+		// "lutris-GE-Proton9-1-x86_64/"). Stripping it means install always
+		// lands the runner's contents directly under the staging extract
+		// dir, regardless of what that directory happened to be called.
+		StripComponents: 1,
+	},
+}
+
+// resolveFlavor looks up a flavor by name, defaulting to "proton-ge".
+func resolveFlavor(name string) (Flavor, error) {
+	if name == "" {
+		name = "proton-ge"
+	}
+
+	f, ok := flavors[name]
+	if !ok {
+		return Flavor{}, fmt.Errorf("unknown flavor %q (known: proton-ge, wine-ge)", name)
+	}
+	return f, nil
+}
+
+// installDir resolves f's install directory under $HOME, creating it if it
+// doesn't already exist.
+func (f Flavor) installDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("getting home directory: %w", err)
+	}
+
+	dir := filepath.Join(homeDir, f.InstallDir)
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", fmt.Errorf("could not find or create %s: %w", dir, err)
+		}
+	}
+
+	return dir, nil
+}
+
+// stagingRoot resolves the directory under the user's cache dir where f's
+// in-progress installs are downloaded, verified, and extracted before being
+// renamed into installDir, creating it if it doesn't already exist. Staging
+// outside installDir means an interrupted or failed install can never show
+// up next to real versions in `list`.
+func (f Flavor) stagingRoot() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("getting cache directory: %w", err)
+	}
+
+	dir := filepath.Join(cacheDir, "gloriouseggroll-downloader", f.Name)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("could not create %s: %w", dir, err)
+	}
+
+	return dir, nil
+}
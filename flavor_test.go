@@ -0,0 +1,61 @@
+package main
+
+import "testing"
+
+func TestResolveFlavor(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantName string
+		wantErr  bool
+	}{
+		{name: "empty defaults to proton-ge", input: "", wantName: "proton-ge"},
+		{name: "proton-ge", input: "proton-ge", wantName: "proton-ge"},
+		{name: "wine-ge", input: "wine-ge", wantName: "wine-ge"},
+		{name: "unknown flavor", input: "bottles-ge", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := resolveFlavor(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resolveFlavor(%q) error = nil, want error", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveFlavor(%q) error = %v", tt.input, err)
+			}
+			if f.Name != tt.wantName {
+				t.Errorf("resolveFlavor(%q).Name = %q, want %q", tt.input, f.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestFlavorAssetMatcher(t *testing.T) {
+	tests := []struct {
+		flavor string
+		asset  string
+		want   bool
+	}{
+		{flavor: "proton-ge", asset: "GE-Proton9-1.tar.gz", want: true},
+		{flavor: "proton-ge", asset: "GE-Proton9-1.sha512sum", want: false},
+		{flavor: "proton-ge", asset: "GE-Proton9-1.sha512sum.gz", want: false},
+		{flavor: "wine-ge", asset: "wine-lutris-GE-Proton9-1-x86_64.tar.gz", want: true},
+		{flavor: "wine-ge", asset: "wine-lutris-GE-Proton9-1-x86_64.tar.xz", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flavor+"/"+tt.asset, func(t *testing.T) {
+			f, err := resolveFlavor(tt.flavor)
+			if err != nil {
+				t.Fatalf("resolveFlavor(%q) error = %v", tt.flavor, err)
+			}
+			if got := f.AssetMatcher(tt.asset); got != tt.want {
+				t.Errorf("AssetMatcher(%q) = %v, want %v", tt.asset, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// maxRetries bounds how many attempts withRetry makes (the first try
+// included) while a transient network or server error keeps recurring.
+const maxRetries = 5
+
+var httpClient = &http.Client{}
+
+// retryableError marks an error from a request attempt as transient (a
+// network failure or 5xx response), telling withRetry it's worth another
+// attempt. Errors that aren't wrapped this way (e.g. a 404) abort immediately.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// withRetry calls fn, retrying with exponential backoff while it returns a
+// *retryableError, up to maxRetries attempts.
+func withRetry(fn func() error) error {
+	var err error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+
+		var re *retryableError
+		if !asRetryable(err, &re) {
+			return err
+		}
+
+		if attempt == maxRetries-1 {
+			break
+		}
+
+		backoff := time.Duration(1<<uint(attempt)) * time.Second
+		fmt.Fprintf(os.Stderr, "Transient error: %v (attempt %d/%d, retrying in %s)\n", re.err, attempt+1, maxRetries, backoff)
+		time.Sleep(backoff)
+	}
+	return err
+}
+
+func asRetryable(err error, target **retryableError) bool {
+	re, ok := err.(*retryableError)
+	if ok {
+		*target = re
+	}
+	return ok
+}
+
+// githubAuthHeader sets a Bearer Authorization header from GITHUB_TOKEN, when
+// it is set, so API and asset requests aren't limited to GitHub's
+// 60-requests-per-hour unauthenticated rate limit. GITHUB_USER is not read,
+// and no header is set when GITHUB_TOKEN is empty.
+func githubAuthHeader(req *http.Request) {
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// logRateLimit surfaces GitHub's rate limit headers so users can see how
+// close they are to being throttled.
+func logRateLimit(resp *http.Response) {
+	remaining := resp.Header.Get("X-RateLimit-Remaining")
+	limit := resp.Header.Get("X-RateLimit-Limit")
+	if remaining == "" {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "GitHub API rate limit: %s/%s remaining\n", remaining, limit)
+}
+
+// githubGet issues an authenticated GET to url, retrying transient failures
+// with exponential backoff, and returns the successful response. The caller
+// is responsible for closing resp.Body.
+func githubGet(url string) (*http.Response, error) {
+	var resp *http.Response
+	err := withRetry(func() error {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		githubAuthHeader(req)
+
+		r, err := httpClient.Do(req)
+		if err != nil {
+			return &retryableError{err}
+		}
+
+		if r.StatusCode >= 500 {
+			r.Body.Close()
+			return &retryableError{fmt.Errorf("server error: %s", r.Status)}
+		}
+		if r.StatusCode >= 400 {
+			r.Body.Close()
+			return fmt.Errorf("request to %s failed: %s", url, r.Status)
+		}
+
+		logRateLimit(r)
+		resp = r
+		return nil
+	})
+	return resp, err
+}
+
+// progressReader wraps an io.Reader, printing percent-complete and transfer
+// speed to stderr as it's read. total is the full expected size in bytes
+// (including any bytes already on disk from a resumed download); read starts
+// at the byte offset already accounted for.
+type progressReader struct {
+	reader    io.Reader
+	total     int64
+	read      int64
+	startRead int64
+	start     time.Time
+	lastPrint time.Time
+}
+
+func newProgressReader(r io.Reader, total, alreadyRead int64) *progressReader {
+	now := time.Now()
+	return &progressReader{reader: r, total: total, read: alreadyRead, startRead: alreadyRead, start: now, lastPrint: now}
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.reader.Read(buf)
+	p.read += int64(n)
+
+	now := time.Now()
+	if now.Sub(p.lastPrint) > 200*time.Millisecond || err == io.EOF {
+		p.print(now)
+		p.lastPrint = now
+	}
+
+	return n, err
+}
+
+func (p *progressReader) print(now time.Time) {
+	elapsed := now.Sub(p.start).Seconds()
+	speed := float64(p.read-p.startRead) / 1024 / 1024
+	if elapsed > 0 {
+		speed /= elapsed
+	}
+
+	if p.total > 0 {
+		percent := float64(p.read) / float64(p.total) * 100
+		fmt.Fprintf(os.Stderr, "\r%.1f%% (%.1f MiB/s)", percent, speed)
+	} else {
+		fmt.Fprintf(os.Stderr, "\r%.1f MiB downloaded (%.1f MiB/s)", float64(p.read)/1024/1024, speed)
+	}
+}
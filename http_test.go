@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithRetry(t *testing.T) {
+	t.Run("succeeds after transient failures", func(t *testing.T) {
+		attempts := 0
+		err := withRetry(func() error {
+			attempts++
+			if attempts < 3 {
+				return &retryableError{errors.New("connection reset")}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("withRetry() error = %v, want nil", err)
+		}
+		if attempts != 3 {
+			t.Errorf("attempts = %d, want 3", attempts)
+		}
+	})
+
+	t.Run("stops immediately on non-retryable error", func(t *testing.T) {
+		attempts := 0
+		wantErr := errors.New("404 not found")
+		err := withRetry(func() error {
+			attempts++
+			return wantErr
+		})
+		if err != wantErr {
+			t.Fatalf("withRetry() error = %v, want %v", err, wantErr)
+		}
+		if attempts != 1 {
+			t.Errorf("attempts = %d, want 1", attempts)
+		}
+	})
+}
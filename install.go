@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+func newInstallCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "install [version]",
+		Short: "Install a GE-Proton or Wine-GE version (defaults to latest)",
+		Long: "Install a GE-Proton or Wine-GE version (defaults to latest).\n\n" +
+			"Every download is checksum-verified against the release's published\n" +
+			".sha512sum. GPG signature verification is also attempted for releases\n" +
+			"that publish an .asc signature, but no signing key is bundled with this\n" +
+			"tool yet, so that check is skipped with a warning unless you set\n" +
+			"GE_GPG_PUBKEY_PATH to a local copy of the Glorious Eggroll public key.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flavor, err := flavorFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+			tag := ""
+			if len(args) == 1 {
+				tag = args[0]
+			}
+			return runInstall(flavor, tag)
+		},
+	}
+}
+
+func newUpdateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "update",
+		Short: "Install the latest version, if it isn't already installed",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flavor, err := flavorFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+			return runInstall(flavor, "")
+		},
+	}
+}
+
+// runInstall downloads, verifies, and extracts the given GE-Proton release
+// tag (or the latest release, if tag is empty). Extraction happens in a
+// staging directory under the user's cache dir first, which is renamed into
+// installDir only once everything has succeeded, so a failed or interrupted
+// install never leaves a half-extracted version directory next to real ones
+// that Steam (or `list`) would try to load.
+//
+// The staging directory name is deterministic (keyed by tag, not random), so
+// a retried `install` after a dropped connection finds its previous ".part"
+// file in place and downloadLatestRelease resumes it instead of starting the
+// ~400 MB archive over - so it's deliberately left in place on a download
+// error. Any failure past that point (bad checksum/signature, a corrupt
+// archive, a failed rename) means there's nothing worth resuming, so the
+// staging directory is removed before returning the error.
+func runInstall(flavor Flavor, tag string) error {
+	installDir, err := flavor.installDir()
+	if err != nil {
+		return err
+	}
+
+	tagName, archiveName, archiveURL, sumURL, sigURL, err := getReleaseURL(flavor, tag)
+	if err != nil {
+		return fmt.Errorf("fetching release information: %w", err)
+	}
+
+	destDir := filepath.Join(installDir, tagName)
+	if _, err := os.Stat(destDir); err == nil {
+		fmt.Printf("%s is already installed. Exiting...\n", tagName)
+		return nil
+	}
+
+	stagingRoot, err := flavor.stagingRoot()
+	if err != nil {
+		return fmt.Errorf("creating staging directory: %w", err)
+	}
+	stagingDir := filepath.Join(stagingRoot, "staging-"+tagName)
+	if err := os.MkdirAll(stagingDir, 0755); err != nil {
+		return fmt.Errorf("creating staging directory: %w", err)
+	}
+
+	// archiveName (the asset's real file name), not tagName, so the
+	// .sha512sum lines fetched during verification - which key off the
+	// actual asset name - line up for flavors like Wine-GE where the two
+	// differ.
+	archivePath := filepath.Join(stagingDir, archiveName)
+	fmt.Printf("Downloading %s to %s...\n", archiveURL, archivePath)
+	sha512Sum, err := downloadLatestRelease(archiveURL, archivePath)
+	if err != nil {
+		return fmt.Errorf("downloading release archive: %w", err)
+	}
+
+	fmt.Println("Verifying archive checksum and signature...")
+	if err := verifyRelease(archivePath, sha512Sum, sumURL, sigURL); err != nil {
+		os.RemoveAll(stagingDir)
+		return fmt.Errorf("verifying release archive: %w", err)
+	}
+
+	extractDir := filepath.Join(stagingDir, "extracted")
+	if err := os.MkdirAll(extractDir, 0755); err != nil {
+		os.RemoveAll(stagingDir)
+		return err
+	}
+
+	fmt.Printf("Extracting %s...\n", archivePath)
+	if err := extractTarGzFile(archivePath, extractDir, flavor.StripComponents); err != nil {
+		os.RemoveAll(stagingDir)
+		return fmt.Errorf("extracting release archive: %w", err)
+	}
+
+	// With StripComponents > 0 the archive's own top-level directory (which
+	// isn't necessarily named after tagName - Wine-GE's isn't) has already
+	// been stripped off by extractTarGzFile, so extractDir's contents can be
+	// installed directly. Otherwise (StripComponents == 0, e.g. Proton-GE)
+	// the archive's single top-level directory is still present; find it by
+	// listing extractDir rather than assuming it's named tagName.
+	extractedRoot := extractDir
+	if flavor.StripComponents == 0 {
+		extractedRoot, err = soleTopLevelDir(extractDir)
+		if err != nil {
+			os.RemoveAll(stagingDir)
+			return fmt.Errorf("locating extracted %s contents: %w", tagName, err)
+		}
+	}
+
+	if err := os.Rename(extractedRoot, destDir); err != nil {
+		os.RemoveAll(stagingDir)
+		return fmt.Errorf("installing %s: %w", tagName, err)
+	}
+
+	os.RemoveAll(stagingDir)
+	fmt.Printf("Installed %s to %s\n", tagName, destDir)
+	return nil
+}
+
+// soleTopLevelDir returns the path of dir's single top-level directory
+// entry, erroring if dir contains anything other than exactly one directory.
+// Release archives are expected to unpack into one top-level directory; its
+// name varies by flavor (and isn't always the release tag), so callers
+// locate it this way instead of assuming a name.
+func soleTopLevelDir(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if len(entries) != 1 || !entries[0].IsDir() {
+		return "", fmt.Errorf("expected exactly one top-level directory in %s, found %d entries", dir, len(entries))
+	}
+
+	return filepath.Join(dir, entries[0].Name()), nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List installed versions and what's available upstream",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flavor, err := flavorFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+			return runList(flavor)
+		},
+	}
+}
+
+func runList(flavor Flavor) error {
+	installDir, err := flavor.installDir()
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(installDir)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", installDir, err)
+	}
+
+	fmt.Println("Installed:")
+	installed := false
+	for _, entry := range entries {
+		// Dot-prefixed (hidden) directories are never installed versions,
+		// so skip them rather than reporting one as installed forever.
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		fmt.Printf("  %s\n", entry.Name())
+		installed = true
+	}
+	if !installed {
+		fmt.Println("  (none)")
+	}
+
+	releases, err := listReleases(flavor)
+	if err != nil {
+		return fmt.Errorf("fetching available releases: %w", err)
+	}
+
+	fmt.Println("\nAvailable upstream:")
+	for _, release := range releases {
+		fmt.Printf("  %s\n", release.TagName)
+	}
+
+	return nil
+}
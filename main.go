@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "gloriouseggroll-downloader",
+		Short: "Install and manage GE-Proton and Wine-GE compatibility tool versions",
+	}
+
+	root.PersistentFlags().String("flavor", "proton-ge", "GE flavor to manage (proton-ge, wine-ge)")
+
+	root.AddCommand(newInstallCmd())
+	root.AddCommand(newUpdateCmd())
+	root.AddCommand(newListCmd())
+	root.AddCommand(newRemoveCmd())
+	root.AddCommand(newUseCmd())
+
+	return root
+}
+
+// flavorFromCmd resolves the --flavor flag (inherited from the root command)
+// into a Flavor.
+func flavorFromCmd(cmd *cobra.Command) (Flavor, error) {
+	name, err := cmd.Flags().GetString("flavor")
+	if err != nil {
+		return Flavor{}, err
+	}
+	return resolveFlavor(name)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+func newRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <version>",
+		Short: "Remove an installed version",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flavor, err := flavorFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+			return runRemove(flavor, args[0])
+		},
+	}
+}
+
+func runRemove(flavor Flavor, tag string) error {
+	installDir, err := flavor.installDir()
+	if err != nil {
+		return err
+	}
+
+	if tag == "." || tag == ".." || filepath.Base(tag) != tag {
+		return fmt.Errorf("invalid version %q", tag)
+	}
+
+	versionDir := filepath.Join(installDir, tag)
+	if _, err := os.Stat(versionDir); os.IsNotExist(err) {
+		return fmt.Errorf("%s is not installed", tag)
+	}
+
+	if err := os.RemoveAll(versionDir); err != nil {
+		return fmt.Errorf("removing %s: %w", tag, err)
+	}
+
+	fmt.Printf("Removed %s\n", tag)
+	return nil
+}
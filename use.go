@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+func newUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <version>",
+		Short: "Point the \"current\" symlink at an installed version",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flavor, err := flavorFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+			return runUse(flavor, args[0])
+		},
+	}
+}
+
+// runUse repoints installDir/current, a convenience symlink for scripts and
+// launch options that want "whichever version I last selected" without
+// hard-coding a version, at the given installed version.
+func runUse(flavor Flavor, tag string) error {
+	installDir, err := flavor.installDir()
+	if err != nil {
+		return err
+	}
+
+	versionDir := filepath.Join(installDir, tag)
+	if _, err := os.Stat(versionDir); os.IsNotExist(err) {
+		return fmt.Errorf("%s is not installed", tag)
+	}
+
+	currentLink := filepath.Join(installDir, "current")
+	os.Remove(currentLink)
+	if err := os.Symlink(versionDir, currentLink); err != nil {
+		return fmt.Errorf("linking current to %s: %w", tag, err)
+	}
+
+	fmt.Printf("Now using %s\n", tag)
+	return nil
+}